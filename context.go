@@ -0,0 +1,57 @@
+package log15
+
+import "context"
+
+// ctxKey is an unexported type used as the context.Context key for values
+// stored by this package, so it can't collide with keys defined elsewhere.
+type ctxKey int
+
+const (
+	loggerCtxKey ctxKey = iota
+	annotationsCtxKey
+)
+
+// WithContext returns a copy of ctx carrying l, retrievable with FromContext.
+func WithContext(ctx context.Context, l Logger) context.Context {
+	return context.WithValue(ctx, loggerCtxKey, l)
+}
+
+// FromContext returns the Logger stored in ctx by WithContext (or root if
+// ctx carries none), with any key/value pairs added via Annotate already
+// merged into its context.
+func FromContext(ctx context.Context) Logger {
+	l, ok := ctx.Value(loggerCtxKey).(Logger)
+	if !ok {
+		l = root
+	}
+	if kv := annotations(ctx); len(kv) > 0 {
+		return l.New(kv...)
+	}
+	return l
+}
+
+// Annotate returns a copy of ctx with kv appended to any key/value pairs
+// already annotated on it, letting a call site enrich the log context
+// without holding a Logger reference. FromContext merges these in
+// automatically.
+func Annotate(ctx context.Context, kv ...interface{}) context.Context {
+	existing, _ := ctx.Value(annotationsCtxKey).([]interface{})
+
+	merged := make([]interface{}, 0, len(existing)+len(kv))
+	merged = append(merged, existing...)
+	merged = append(merged, normalize(kv)...)
+
+	return context.WithValue(ctx, annotationsCtxKey, merged)
+}
+
+// annotations returns the key/value pairs accumulated on ctx via Annotate.
+func annotations(ctx context.Context) []interface{} {
+	kv, _ := ctx.Value(annotationsCtxKey).([]interface{})
+	return kv
+}
+
+// WithContext returns a child of l with ctx's Annotate key/value pairs
+// copied into its context.
+func (l *logger) WithContext(ctx context.Context) Logger {
+	return l.New(annotations(ctx)...)
+}
@@ -0,0 +1,60 @@
+package log15
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+// fakeLogger is a minimal Logger used to observe what ctx a caller ends up
+// building, without depending on a concrete Handler/swapHandler.
+type fakeLogger struct {
+	ctx []interface{}
+}
+
+func (f *fakeLogger) New(ctx ...interface{}) Logger {
+	merged := append(append([]interface{}{}, f.ctx...), normalize(ctx)...)
+	return &fakeLogger{ctx: merged}
+}
+func (f *fakeLogger) SetHandler(h Handler)             {}
+func (f *fakeLogger) Trace(msg string, ctx ...interface{}) {}
+func (f *fakeLogger) Debug(msg string, ctx ...interface{}) {}
+func (f *fakeLogger) Info(msg string, ctx ...interface{})  {}
+func (f *fakeLogger) Warn(msg string, ctx ...interface{})  {}
+func (f *fakeLogger) Error(msg string, ctx ...interface{}) {}
+func (f *fakeLogger) Crit(msg string, ctx ...interface{})  {}
+
+func TestFromContextMergesAnnotations(t *testing.T) {
+	base := &fakeLogger{}
+	ctx := WithContext(context.Background(), base)
+	ctx = Annotate(ctx, "requestID", "abc123")
+
+	got, ok := FromContext(ctx).(*fakeLogger)
+	if !ok {
+		t.Fatalf("FromContext returned %T, want *fakeLogger", FromContext(ctx))
+	}
+
+	want := []interface{}{"requestID", "abc123"}
+	if !reflect.DeepEqual(got.ctx, want) {
+		t.Fatalf("FromContext(ctx).ctx = %v, want %v", got.ctx, want)
+	}
+}
+
+func TestFromContextNoAnnotations(t *testing.T) {
+	base := &fakeLogger{}
+	ctx := WithContext(context.Background(), base)
+
+	if got := FromContext(ctx); got != Logger(base) {
+		t.Fatalf("FromContext without annotations should return the stored logger unchanged, got %#v", got)
+	}
+}
+
+func TestAnnotateAccumulates(t *testing.T) {
+	ctx := Annotate(context.Background(), "a", 1)
+	ctx = Annotate(ctx, "b", 2)
+
+	want := []interface{}{"a", 1, "b", 2}
+	if got := annotations(ctx); !reflect.DeepEqual(got, want) {
+		t.Fatalf("annotations(ctx) = %v, want %v", got, want)
+	}
+}
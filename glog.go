@@ -0,0 +1,232 @@
+package log15
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// packagePrefix is the function-name prefix shared by everything defined in
+// this package, used by callerOutsidePackage to walk past our own dispatch
+// frames (GlogHandler.Log, middleware wrappers, swapHandler.Log, ...) to the
+// frame that actually issued the log call, regardless of how many such
+// frames sit in between.
+var packagePrefix = func() string {
+	pc, _, _, _ := runtime.Caller(0)
+	name := runtime.FuncForPC(pc).Name()
+
+	slash := strings.LastIndex(name, "/")
+	rest := name
+	if slash >= 0 {
+		rest = name[slash+1:]
+	}
+	if dot := strings.Index(rest, "."); dot >= 0 {
+		return name[:len(name)-len(rest)+dot+1]
+	}
+	return name
+}()
+
+// callerOutsidePackage walks the stack starting at its caller and returns
+// the first frame whose function does not belong to this package. Unlike a
+// fixed runtime.Caller(N) skip count, this stays correct no matter how many
+// Middleware layers or other in-package wrappers sit between swapHandler.Log
+// and a given Handler's Log method.
+func callerOutsidePackage() (pc uintptr, file string, line int, ok bool) {
+	var pcs [32]uintptr
+	n := runtime.Callers(2, pcs[:])
+	if n == 0 {
+		return 0, "", 0, false
+	}
+
+	frames := runtime.CallersFrames(pcs[:n])
+	for {
+		frame, more := frames.Next()
+		if !strings.HasPrefix(frame.Function, packagePrefix) {
+			return frame.PC, frame.File, frame.Line, true
+		}
+		if !more {
+			return 0, "", 0, false
+		}
+	}
+}
+
+// GlogHandler is a log handler that mimics the filtering features of Google's
+// glog logger: a global verbosity ceiling, plus fine-grained overrides for
+// specific files or packages via Vmodule, and an optional stack dump at a
+// given file:line via BacktraceAt.
+type GlogHandler struct {
+	origin Handler // The origin handler this wraps
+
+	level     uint32 // Current log level, atomically accessible
+	override  uint32 // Flag whether vmodule overrides are active
+	backtrace uint32 // Flag whether a backtrace location is set
+
+	patterns  []vmodulePattern // Current list of vmodule patterns to match
+	siteCache sync.Map         // PC -> Lvl cache of callsite pattern evaluations
+	location  string           // file:line location where to do a stackdump
+	lock      sync.RWMutex     // Protects patterns and location
+}
+
+// vmodulePattern associates a compiled glob pattern with the verbosity level
+// it grants to matching source files.
+type vmodulePattern struct {
+	re    *regexp.Regexp
+	level Lvl
+}
+
+// NewGlogHandler creates a log Handler with glog-style verbosity filtering
+// that wraps and forwards accepted records to h.
+func NewGlogHandler(h Handler) *GlogHandler {
+	return &GlogHandler{origin: h}
+}
+
+// Verbosity sets the glog verbosity ceiling. Individual files or packages can
+// be raised above this ceiling with Vmodule; records within the ceiling are
+// always forwarded, so Vmodule cannot drop a file below it.
+func (h *GlogHandler) Verbosity(level Lvl) {
+	atomic.StoreUint32(&h.level, uint32(level))
+}
+
+// Vmodule sets the glog vmodule pattern, a comma-separated list of
+// pattern=level rules (e.g. "p2p/*=4,rpc/handler.go=5"), matched against the
+// file path of the log call's caller. A rule with no "*" must match the
+// caller's path exactly up to a "/" or path boundary, so "rpc/handler.go"
+// does not also match "rpc/handler.gox" or "old_rpc/handler.go.bak".
+func (h *GlogHandler) Vmodule(spec string) error {
+	var patterns []vmodulePattern
+	for _, rule := range strings.Split(spec, ",") {
+		if rule == "" {
+			continue
+		}
+		parts := strings.Split(rule, "=")
+		if len(parts) != 2 {
+			return fmt.Errorf("invalid vmodule rule %q", rule)
+		}
+		level, err := strconv.Atoi(parts[1])
+		if err != nil {
+			return fmt.Errorf("invalid verbosity in vmodule rule %q: %v", rule, err)
+		}
+
+		segments := strings.Split(parts[0], "*")
+		for i, seg := range segments {
+			segments[i] = regexp.QuoteMeta(seg)
+		}
+		// Anchor the pattern to a path boundary on either side, so a
+		// wildcard-free rule only matches the exact file it names.
+		matcher := "(^|/)" + strings.Join(segments, ".*") + "$"
+
+		re, err := regexp.Compile(matcher)
+		if err != nil {
+			return fmt.Errorf("invalid vmodule pattern %q: %v", parts[0], err)
+		}
+		patterns = append(patterns, vmodulePattern{re: re, level: Lvl(level)})
+	}
+
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	h.patterns = patterns
+	h.siteCache = sync.Map{}
+	atomic.StoreUint32(&h.override, uint32(len(patterns)))
+
+	return nil
+}
+
+// BacktraceAt sets a "file.go:123" location at which point the handler will
+// dump the full goroutine stack into the record's context under "stack".
+func (h *GlogHandler) BacktraceAt(spec string) error {
+	parts := strings.Split(spec, ":")
+	if len(parts) != 2 {
+		return errors.New("backtrace location should be of the form file.go:123")
+	}
+	line, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return errors.New("backtrace location should be of the form file.go:123")
+	}
+
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	h.location = fmt.Sprintf("%s:%d", parts[0], line)
+	if line == 0 {
+		atomic.StoreUint32(&h.backtrace, 0)
+	} else {
+		atomic.StoreUint32(&h.backtrace, 1)
+	}
+	return nil
+}
+
+// Log implements Handler. It resolves the call site of the original log
+// statement, applies the backtrace and vmodule filters, and forwards the
+// record to the origin handler if it passes.
+func (h *GlogHandler) Log(r *Record) error {
+	pc, file, line, ok := callerOutsidePackage()
+
+	if ok && atomic.LoadUint32(&h.backtrace) > 0 {
+		site := file
+		if slash := strings.LastIndex(site, "/"); slash >= 0 {
+			site = site[slash+1:]
+		}
+
+		h.lock.RLock()
+		match := h.location == fmt.Sprintf("%s:%d", site, line)
+		h.lock.RUnlock()
+
+		if match {
+			buf := make([]byte, 1024*1024)
+			buf = buf[:runtime.Stack(buf, true)]
+			r.Ctx = append(r.Ctx, "stack", string(buf))
+		}
+	}
+
+	// The global ceiling always lets the record through.
+	if atomic.LoadUint32(&h.level) >= uint32(r.Lvl) {
+		return h.origin.Log(r)
+	}
+	// No vmodule overrides configured: the ceiling is authoritative.
+	if atomic.LoadUint32(&h.override) == 0 || !ok {
+		return nil
+	}
+
+	h.lock.RLock()
+	cached, hit := h.siteCache.Load(pc)
+	h.lock.RUnlock()
+
+	if hit {
+		if cached.(Lvl) >= r.Lvl {
+			return h.origin.Log(r)
+		}
+		return nil
+	}
+
+	lvl := h.matchVmodule(file)
+
+	h.lock.RLock()
+	h.siteCache.Store(pc, lvl)
+	h.lock.RUnlock()
+
+	if lvl >= r.Lvl {
+		return h.origin.Log(r)
+	}
+	return nil
+}
+
+// matchVmodule returns the verbosity level of the first current Vmodule
+// pattern (in rule order) that matches file, or LvlCrit (the most
+// restrictive level) if none match.
+func (h *GlogHandler) matchVmodule(file string) Lvl {
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+
+	for _, p := range h.patterns {
+		if p.re.MatchString(file) {
+			return p.level
+		}
+	}
+	return LvlCrit
+}
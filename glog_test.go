@@ -0,0 +1,137 @@
+package log15
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestLvlAlignedString(t *testing.T) {
+	cases := []struct {
+		lvl  Lvl
+		want string
+	}{
+		{LvlCrit, "CRIT "},
+		{LvlError, "ERROR"},
+		{LvlWarn, "WARN "},
+		{LvlInfo, "INFO "},
+		{LvlDebug, "DEBUG"},
+		{LvlTrace, "TRACE"},
+	}
+	for _, c := range cases {
+		if got := c.lvl.AlignedString(); got != c.want || len(got) != 5 {
+			t.Errorf("Lvl(%d).AlignedString() = %q, want %q", c.lvl, got, c.want)
+		}
+	}
+}
+
+func TestGlogHandlerVmoduleAnchoring(t *testing.T) {
+	h := NewGlogHandler(handlerFunc(func(*Record) error { return nil }))
+	if err := h.Vmodule("rpc/handler.go=5"); err != nil {
+		t.Fatalf("Vmodule: %v", err)
+	}
+
+	if got := h.matchVmodule("rpc/handler.go"); got != 5 {
+		t.Errorf("matchVmodule(%q) = %v, want 5", "rpc/handler.go", got)
+	}
+	if got := h.matchVmodule("path/rpc/handler.go"); got != 5 {
+		t.Errorf("matchVmodule(%q) = %v, want 5 (directory prefix should still match)", "path/rpc/handler.go", got)
+	}
+	for _, file := range []string{"rpc/handler.gox", "old_rpc/handler.go.bak", "other_rpc/handler.go"} {
+		if got := h.matchVmodule(file); got != LvlCrit {
+			t.Errorf("matchVmodule(%q) = %v, want LvlCrit (no match)", file, got)
+		}
+	}
+}
+
+func TestGlogHandlerVmoduleWildcard(t *testing.T) {
+	h := NewGlogHandler(handlerFunc(func(*Record) error { return nil }))
+	if err := h.Vmodule("p2p/*=4"); err != nil {
+		t.Fatalf("Vmodule: %v", err)
+	}
+	if got := h.matchVmodule("p2p/server.go"); got != 4 {
+		t.Errorf("matchVmodule(%q) = %v, want 4", "p2p/server.go", got)
+	}
+	if got := h.matchVmodule("rpc/server.go"); got != LvlCrit {
+		t.Errorf("matchVmodule(%q) = %v, want LvlCrit (no match)", "rpc/server.go", got)
+	}
+}
+
+// TestCallerOutsidePackageStableAcrossDepth guards against the bug where a
+// fixed runtime.Caller(N) skip count resolved to the wrong frame once the
+// call went through one or more Middleware layers: the resolved call site
+// must not depend on how many in-package wrapper frames sit in between.
+func TestCallerOutsidePackageStableAcrossDepth(t *testing.T) {
+	identity := func(next Handler) Handler {
+		return handlerFunc(func(r *Record) error { return next.Log(r) })
+	}
+
+	wrap := func(h Handler, depth int) Handler {
+		mw := make([]Middleware, depth)
+		for i := range mw {
+			mw[i] = identity
+		}
+		return ChainHandler(h, mw...)
+	}
+
+	var files []string
+	var lines []int
+
+	for _, depth := range []int{0, 1, 3} {
+		probe := handlerFunc(func(*Record) error {
+			_, file, line, ok := callerOutsidePackage()
+			if !ok {
+				t.Fatalf("depth %d: expected a caller outside package log15", depth)
+			}
+			files = append(files, file)
+			lines = append(lines, line)
+			return nil
+		})
+		wrap(probe, depth).Log(&Record{Lvl: LvlInfo, Msg: "m"})
+	}
+
+	for i := 1; i < len(files); i++ {
+		if files[i] != files[0] || lines[i] != lines[0] {
+			t.Fatalf("call site resolution changed with middleware depth: %s:%d vs %s:%d", files[0], lines[0], files[i], lines[i])
+		}
+	}
+}
+
+// TestGlogHandlerVmoduleRaceWithLog guards against a race between Vmodule
+// reassigning h.siteCache and Log's concurrent Load/Store on it: runtime
+// reconfiguration of verbosity is the exact use case this handler exists
+// for, so it must be safe to call Vmodule while records are in flight.
+func TestGlogHandlerVmoduleRaceWithLog(t *testing.T) {
+	h := NewGlogHandler(handlerFunc(func(*Record) error { return nil }))
+
+	var wg sync.WaitGroup
+	stop := make(chan struct{})
+
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+				h.Vmodule("glog_test.go=9")
+			}
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+				h.Log(&Record{Lvl: LvlDebug, Msg: "m"})
+			}
+		}
+	}()
+
+	time.Sleep(20 * time.Millisecond)
+	close(stop)
+	wg.Wait()
+}
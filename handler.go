@@ -0,0 +1,44 @@
+package log15
+
+import (
+	"sync/atomic"
+	"unsafe"
+)
+
+// A Handler deals with the log records created by a Logger.
+type Handler interface {
+	Log(r *Record) error
+}
+
+// FuncHandler returns a Handler that logs records with the given function.
+type FuncHandler func(r *Record) error
+
+func (h FuncHandler) Log(r *Record) error {
+	return h(r)
+}
+
+// DiscardHandler reports success for all writes but does nothing.
+// It is the default handler for new Loggers.
+func DiscardHandler() Handler {
+	return FuncHandler(func(r *Record) error {
+		return nil
+	})
+}
+
+// swapHandler wraps another handler that may be swapped out
+// dynamically at runtime in a thread-safe fashion.
+type swapHandler struct {
+	handler unsafe.Pointer
+}
+
+func (h *swapHandler) Log(r *Record) error {
+	return (*(*Handler)(atomic.LoadPointer(&h.handler))).Log(r)
+}
+
+func (h *swapHandler) Swap(newHandler Handler) {
+	atomic.StorePointer(&h.handler, unsafe.Pointer(&newHandler))
+}
+
+func (h *swapHandler) Get() Handler {
+	return *(*Handler)(atomic.LoadPointer(&h.handler))
+}
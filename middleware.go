@@ -0,0 +1,181 @@
+package log15
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Middleware wraps a Handler with cross-cutting behaviour such as sampling,
+// redaction or deduplication.
+type Middleware func(next Handler) Handler
+
+// ChainHandler applies mw to h in order, so that the first middleware in mw
+// is the outermost: ChainHandler(h, a, b).Log() calls a, which calls b,
+// which calls h.
+func ChainHandler(h Handler, mw ...Middleware) Handler {
+	for i := len(mw) - 1; i >= 0; i-- {
+		h = mw[i](h)
+	}
+	return h
+}
+
+// Use installs mw around l's current handler, outermost first.
+func (l *logger) Use(mw ...Middleware) {
+	l.SetHandler(ChainHandler(l.h.Get(), mw...))
+}
+
+// handlerFunc is a Handler backed by a plain function, used by the
+// middlewares below to avoid declaring a named type for each one.
+type handlerFunc func(r *Record) error
+
+func (f handlerFunc) Log(r *Record) error {
+	return f(r)
+}
+
+// tokenBucket is a simple per-key token bucket used by SamplingMiddleware.
+type tokenBucket struct {
+	tokens   float64
+	lastFill time.Time
+}
+
+// SamplingMiddleware rate-limits records sharing the same (Lvl, Msg) with a
+// token bucket keyed by (Lvl, Msg): each bucket holds at most perSecond
+// tokens and refills at perSecond tokens per second, and a record is dropped
+// when its bucket has no whole token left.
+func SamplingMiddleware(perSecond int) Middleware {
+	var (
+		mu      sync.Mutex
+		buckets = make(map[Lvl]map[string]*tokenBucket)
+	)
+
+	return func(next Handler) Handler {
+		return handlerFunc(func(r *Record) error {
+			now := time.Now()
+
+			mu.Lock()
+			byMsg, ok := buckets[r.Lvl]
+			if !ok {
+				byMsg = make(map[string]*tokenBucket)
+				buckets[r.Lvl] = byMsg
+			}
+			b, ok := byMsg[r.Msg]
+			if !ok {
+				b = &tokenBucket{tokens: float64(perSecond), lastFill: now}
+				byMsg[r.Msg] = b
+			}
+
+			elapsed := now.Sub(b.lastFill).Seconds()
+			b.tokens += elapsed * float64(perSecond)
+			if b.tokens > float64(perSecond) {
+				b.tokens = float64(perSecond)
+			}
+			b.lastFill = now
+
+			allow := b.tokens >= 1
+			if allow {
+				b.tokens--
+			}
+			mu.Unlock()
+
+			if !allow {
+				return nil
+			}
+			return next.Log(r)
+		})
+	}
+}
+
+// RedactMiddleware replaces the value of any ctx key in keys with "***"
+// before the record reaches the wrapped handler. Useful for scrubbing
+// passwords, tokens and other sensitive values from logs.
+func RedactMiddleware(keys ...string) Middleware {
+	redact := make(map[string]struct{}, len(keys))
+	for _, k := range keys {
+		redact[k] = struct{}{}
+	}
+
+	return func(next Handler) Handler {
+		return handlerFunc(func(r *Record) error {
+			for i := 0; i+1 < len(r.Ctx); i += 2 {
+				key, ok := r.Ctx[i].(string)
+				if !ok {
+					continue
+				}
+				if _, found := redact[key]; found {
+					r.Ctx[i+1] = "***"
+				}
+			}
+			return next.Log(r)
+		})
+	}
+}
+
+// dedupEntry tracks the suppressed-duplicate count for a key, and the
+// template record to replay that count under once the window closes.
+type dedupEntry struct {
+	record *Record
+	count  int
+	timer  *time.Timer
+}
+
+// DedupMiddleware forwards the first occurrence of a record (same Lvl, Msg
+// and Ctx) immediately, then collapses any further duplicates seen within
+// window into a single follow-up record carrying a "count" field. The
+// window is a fixed deadline set on the first occurrence, not reset by
+// later duplicates, so a sustained burst still flushes periodically instead
+// of suppressing its duplicates forever.
+func DedupMiddleware(window time.Duration) Middleware {
+	var (
+		mu      sync.Mutex
+		entries = make(map[string]*dedupEntry)
+	)
+
+	return func(next Handler) Handler {
+		var flush func(key string)
+		flush = func(key string) {
+			mu.Lock()
+			e, ok := entries[key]
+			if ok {
+				delete(entries, key)
+			}
+			mu.Unlock()
+
+			if !ok || e.count == 0 {
+				return
+			}
+			// e.record was already handed to next.Log for the first
+			// occurrence, so clone it rather than mutating it in place -
+			// a retaining handler must not see that delivery change
+			// underneath it.
+			clone := *e.record
+			clone.Ctx = append(append([]interface{}{}, e.record.Ctx...), "count", e.count+1)
+			next.Log(&clone)
+		}
+
+		return handlerFunc(func(r *Record) error {
+			key := dedupKey(r)
+
+			mu.Lock()
+			if e, ok := entries[key]; ok {
+				e.count++
+				mu.Unlock()
+				return nil
+			}
+
+			entries[key] = &dedupEntry{
+				record: r,
+				timer:  time.AfterFunc(window, func() { flush(key) }),
+			}
+			mu.Unlock()
+
+			return next.Log(r)
+		})
+	}
+}
+
+// dedupKey builds a key identifying records that should be considered
+// duplicates of one another.
+func dedupKey(r *Record) string {
+	return fmt.Sprintf("%d|%s|%v", r.Lvl, r.Msg, r.Ctx)
+}
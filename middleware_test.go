@@ -0,0 +1,119 @@
+package log15
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+type countingHandler struct {
+	mu      sync.Mutex
+	records []*Record
+}
+
+func (h *countingHandler) Log(r *Record) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.records = append(h.records, r)
+	return nil
+}
+
+func (h *countingHandler) len() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.records)
+}
+
+func (h *countingHandler) at(i int) *Record {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.records[i]
+}
+
+func TestRedactMiddleware(t *testing.T) {
+	rec := &countingHandler{}
+	h := ChainHandler(rec, RedactMiddleware("password"))
+
+	h.Log(&Record{Msg: "login", Ctx: []interface{}{"user", "bob", "password", "hunter2"}})
+
+	got := rec.records[0].Ctx
+	if got[3] != "***" {
+		t.Fatalf("password not redacted: %v", got)
+	}
+	if got[1] != "bob" {
+		t.Fatalf("unrelated key redacted: %v", got)
+	}
+}
+
+func TestSamplingMiddlewareCapsBurst(t *testing.T) {
+	rec := &countingHandler{}
+	h := ChainHandler(rec, SamplingMiddleware(2))
+
+	for i := 0; i < 10; i++ {
+		h.Log(&Record{Lvl: LvlInfo, Msg: "spam"})
+	}
+
+	if n := rec.len(); n != 2 {
+		t.Fatalf("expected token bucket to cap an instant burst at 2, got %d", n)
+	}
+}
+
+// TestDedupMiddlewareLogsFirstOccurrenceImmediately guards against the bug
+// where every record, including the very first one, was held back until
+// the dedup window elapsed.
+func TestDedupMiddlewareLogsFirstOccurrenceImmediately(t *testing.T) {
+	rec := &countingHandler{}
+	h := ChainHandler(rec, DedupMiddleware(time.Hour))
+
+	h.Log(&Record{Lvl: LvlInfo, Msg: "boom"})
+
+	if n := rec.len(); n != 1 {
+		t.Fatalf("expected the first occurrence to be forwarded immediately, got %d records", n)
+	}
+}
+
+// TestDedupMiddlewareFlushesDuringSustainedBurst guards against the bug
+// where a duplicate arriving before the window elapsed reset the timer,
+// so a burst faster than the window suppressed every record after the
+// first forever instead of periodically flushing a count.
+func TestDedupMiddlewareFlushesDuringSustainedBurst(t *testing.T) {
+	rec := &countingHandler{}
+	window := 20 * time.Millisecond
+	h := ChainHandler(rec, DedupMiddleware(window))
+
+	deadline := time.Now().Add(8 * window)
+	for time.Now().Before(deadline) {
+		h.Log(&Record{Lvl: LvlError, Msg: "burst"})
+		time.Sleep(window / 4)
+	}
+	time.Sleep(2 * window)
+
+	if n := rec.len(); n < 2 {
+		t.Fatalf("expected a sustained burst to flush more than once, got %d records", n)
+	}
+}
+
+// TestDedupMiddlewareDoesNotMutateDeliveredRecord guards against the bug
+// where flush() mutated the first occurrence's *Record in place after it
+// had already been handed to next.Log, corrupting it out from under any
+// handler that retained the pointer.
+func TestDedupMiddlewareDoesNotMutateDeliveredRecord(t *testing.T) {
+	rec := &countingHandler{}
+	window := 15 * time.Millisecond
+	h := ChainHandler(rec, DedupMiddleware(window))
+
+	h.Log(&Record{Lvl: LvlWarn, Msg: "dup", Ctx: []interface{}{"a", 1}})
+	h.Log(&Record{Lvl: LvlWarn, Msg: "dup", Ctx: []interface{}{"a", 1}})
+
+	first := rec.at(0)
+	wantLen := len(first.Ctx)
+
+	time.Sleep(4 * window)
+
+	if got := len(first.Ctx); got != wantLen {
+		t.Fatalf("first delivered record's Ctx mutated after the window elapsed: len=%d, want %d", got, wantLen)
+	}
+	if n := rec.len(); n != 2 {
+		t.Fatalf("expected the first occurrence plus one follow-up count record, got %d", n)
+	}
+}
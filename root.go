@@ -0,0 +1,20 @@
+package log15
+
+// root is the implicit logger used by the package-level New and Root
+// helpers and returned by FromContext when a context carries no Logger.
+var root = &logger{[]interface{}{}, new(swapHandler)}
+
+func init() {
+	root.SetHandler(DiscardHandler())
+}
+
+// New returns a new logger with the given context, as a child of the
+// package-level root logger.
+func New(ctx ...interface{}) Logger {
+	return root.New(ctx...)
+}
+
+// Root returns the root logger.
+func Root() Logger {
+	return root
+}
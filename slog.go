@@ -0,0 +1,186 @@
+package log15
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"strings"
+)
+
+// lvlToSlogLevel maps a log15 Lvl onto the equivalent slog.Level.
+func lvlToSlogLevel(lvl Lvl) slog.Level {
+	switch lvl {
+	case LvlCrit:
+		return slog.LevelError + 4
+	case LvlError:
+		return slog.LevelError
+	case LvlWarn:
+		return slog.LevelWarn
+	case LvlInfo:
+		return slog.LevelInfo
+	case LvlDebug:
+		return slog.LevelDebug
+	case LvlTrace:
+		return slog.LevelDebug - 4
+	default:
+		return slog.LevelInfo
+	}
+}
+
+// slogLevelToLvl maps an slog.Level onto the closest log15 Lvl.
+func slogLevelToLvl(level slog.Level) Lvl {
+	switch {
+	case level >= slog.LevelError+4:
+		return LvlCrit
+	case level >= slog.LevelError:
+		return LvlError
+	case level >= slog.LevelWarn:
+		return LvlWarn
+	case level >= slog.LevelInfo:
+		return LvlInfo
+	case level >= slog.LevelDebug:
+		return LvlDebug
+	default:
+		return LvlTrace
+	}
+}
+
+// evaluateLazy resolves a Lazy value by calling its zero-argument Fn via
+// reflection and taking the single return value, or the slice of all of them
+// if it does not return exactly one.
+func evaluateLazy(lz Lazy) interface{} {
+	fn := reflect.ValueOf(lz.Fn)
+	if fn.Kind() != reflect.Func {
+		return fmt.Errorf("ERROR: INVALID LAZY provided: %+v", lz.Fn)
+	}
+	if fn.Type().NumIn() > 0 {
+		return fmt.Errorf("ERROR: INVALID LAZY provided: %+v", lz.Fn)
+	}
+
+	values := fn.Call([]reflect.Value{})
+	if len(values) == 1 {
+		return values[0].Interface()
+	}
+
+	results := make([]interface{}, len(values))
+	for i, v := range values {
+		results[i] = v.Interface()
+	}
+	return results
+}
+
+// ctxToAttrs converts a log15 ctx slice into slog.Attrs, evaluating any Lazy
+// values along the way.
+func ctxToAttrs(ctx []interface{}) []slog.Attr {
+	attrs := make([]slog.Attr, 0, len(ctx)/2)
+	for i := 0; i < len(ctx)-1; i += 2 {
+		key, ok := ctx[i].(string)
+		if !ok {
+			key = fmt.Sprint(ctx[i])
+		}
+
+		val := ctx[i+1]
+		if lz, ok := val.(Lazy); ok {
+			val = evaluateLazy(lz)
+		}
+
+		attrs = append(attrs, slog.Any(key, val))
+	}
+	return attrs
+}
+
+// slogHandler adapts an slog.Handler into a log15 Handler, forwarding
+// Records as slog.Records.
+type slogHandler struct {
+	h slog.Handler
+}
+
+// SlogHandler wraps h so it can be used as a log15 Handler: every Record
+// logged through it is translated into an slog.Record and handed to h.
+func SlogHandler(h slog.Handler) Handler {
+	return &slogHandler{h: h}
+}
+
+func (s *slogHandler) Log(r *Record) error {
+	level := lvlToSlogLevel(r.Lvl)
+	if !s.h.Enabled(context.Background(), level) {
+		return nil
+	}
+
+	rec := slog.NewRecord(r.Time, level, r.Msg, 0)
+	rec.AddAttrs(ctxToAttrs(r.Ctx)...)
+
+	return s.h.Handle(context.Background(), rec)
+}
+
+// slogLogger15Handler is an slog.Handler backed by a log15 Logger, used by
+// NewSlogLogger.
+type slogLogger15Handler struct {
+	l      Logger
+	groups []string
+}
+
+// NewSlogLogger returns an slog.Logger that emits into l, so that code
+// written against log/slog can transparently feed a log15 pipeline.
+func NewSlogLogger(l Logger) *slog.Logger {
+	return slog.New(&slogLogger15Handler{l: l})
+}
+
+func (h *slogLogger15Handler) Enabled(context.Context, slog.Level) bool {
+	return true
+}
+
+func (h *slogLogger15Handler) Handle(_ context.Context, r slog.Record) error {
+	prefix := h.groupPrefix()
+
+	ctx := make([]interface{}, 0, r.NumAttrs()*2)
+	r.Attrs(func(a slog.Attr) bool {
+		ctx = append(ctx, prefix+a.Key, a.Value.Any())
+		return true
+	})
+
+	switch slogLevelToLvl(r.Level) {
+	case LvlTrace:
+		h.l.Trace(r.Message, ctx...)
+	case LvlDebug:
+		h.l.Debug(r.Message, ctx...)
+	case LvlInfo:
+		h.l.Info(r.Message, ctx...)
+	case LvlWarn:
+		h.l.Warn(r.Message, ctx...)
+	case LvlError:
+		h.l.Error(r.Message, ctx...)
+	case LvlCrit:
+		h.l.Crit(r.Message, ctx...)
+	}
+	return nil
+}
+
+func (h *slogLogger15Handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	prefix := h.groupPrefix()
+
+	kv := make([]interface{}, 0, len(attrs)*2)
+	for _, a := range attrs {
+		kv = append(kv, prefix+a.Key, a.Value.Any())
+	}
+
+	return &slogLogger15Handler{l: h.l.New(kv...), groups: h.groups}
+}
+
+func (h *slogLogger15Handler) WithGroup(name string) slog.Handler {
+	groups := make([]string, len(h.groups)+1)
+	copy(groups, h.groups)
+	groups[len(h.groups)] = name
+
+	return &slogLogger15Handler{l: h.l, groups: groups}
+}
+
+// groupPrefix returns the accumulated WithGroup names as a dotted key
+// prefix, e.g. "a.b." for WithGroup("a").WithGroup("b").
+func (h *slogLogger15Handler) groupPrefix() string {
+	if len(h.groups) == 0 {
+		return ""
+	}
+	return strings.Join(h.groups, ".") + "."
+}
@@ -0,0 +1,48 @@
+package log15
+
+import (
+	"log/slog"
+	"reflect"
+	"testing"
+)
+
+func TestLvlSlogLevelRoundTrip(t *testing.T) {
+	for _, lvl := range []Lvl{LvlCrit, LvlError, LvlWarn, LvlInfo, LvlDebug, LvlTrace} {
+		if got := slogLevelToLvl(lvlToSlogLevel(lvl)); got != lvl {
+			t.Errorf("slogLevelToLvl(lvlToSlogLevel(%v)) = %v, want %v", lvl, got, lvl)
+		}
+	}
+}
+
+func TestEvaluateLazy(t *testing.T) {
+	got := evaluateLazy(Lazy{Fn: func() string { return "computed" }})
+	if got != "computed" {
+		t.Errorf("evaluateLazy = %v, want %q", got, "computed")
+	}
+}
+
+func TestCtxToAttrsEvaluatesLazy(t *testing.T) {
+	attrs := ctxToAttrs([]interface{}{"n", Lazy{Fn: func() int { return 42 }}})
+	if len(attrs) != 1 || attrs[0].Key != "n" || attrs[0].Value.Any() != int64(42) {
+		t.Fatalf("ctxToAttrs = %v, want [n=42]", attrs)
+	}
+}
+
+func TestSlogLogger15HandlerGroupPrefix(t *testing.T) {
+	base := &fakeLogger{}
+
+	var h slog.Handler = &slogLogger15Handler{l: base}
+	h = h.WithGroup("a")
+	h = h.WithGroup("b")
+	h = h.WithAttrs([]slog.Attr{slog.String("key", "val")})
+
+	final, ok := h.(*slogLogger15Handler).l.(*fakeLogger)
+	if !ok {
+		t.Fatalf("expected *fakeLogger, got %T", h.(*slogLogger15Handler).l)
+	}
+
+	want := []interface{}{"a.b.key", "val"}
+	if !reflect.DeepEqual(final.ctx, want) {
+		t.Fatalf("got ctx %v, want %v", final.ctx, want)
+	}
+}